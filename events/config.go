@@ -2,13 +2,40 @@ package events
 
 import "sync"
 
+// ModeSimple and ModePath select how a Dispatcher interprets the `*`
+// pattern character found in event IDs registered through Register. See
+// Config.Mode.
+const (
+    ModeSimple uint8 = iota
+    ModePath
+)
+
+// PolicyBlock, PolicyDrop and PolicyError select what a Dispatcher in consume
+// mode does with an event dispatched while its queue is full. See
+// Config.OnQueueFull.
+const (
+    PolicyBlock QueueFullPolicy = iota
+    PolicyDrop
+    PolicyError
+)
+
+// QueueFullPolicy picks the back-pressure behavior of a Dispatcher in
+// consume mode when its queue is full. See Config.ShouldConsume.
+type QueueFullPolicy uint8
+
 // Config holds config information for events.Dispatcher.
 // waitGroup can be nil.
 type Config struct {
-    isAsync    bool
-    isFacade   bool
-    shouldWait bool
-    waitGroup  *sync.WaitGroup
+    isAsync         bool
+    isFacade        bool
+    shouldWait      bool
+    waitGroup       *sync.WaitGroup
+    mode            uint8
+    onListenerError func(EventId, error)
+    isConsuming     bool
+    workers         int
+    bufferSize      int
+    queueFullPolicy QueueFullPolicy
 }
 
 // newDefaultConfig builds a new config struct instance.
@@ -18,9 +45,25 @@ func newDefaultConfig() *Config {
         isFacade:   false,
         shouldWait: true,
         waitGroup:  nil,
+        mode:       ModeSimple,
     }
 }
 
+// Mode sets how the dispatcher interprets the `*` pattern character in event
+// IDs registered through Register. ModeSimple treats a trailing `*` as
+// matching anything to the end of the ID (e.g. "user.*" matches
+// "user.created" and "user.profile.updated"). ModePath treats the ID as a
+// dotted path where `*` matches a single segment and `**` matches any
+// remaining segments (e.g. "user.*.created" matches "user.admin.created"
+// but not "user.admin.profile.created", while "user.**" matches everything
+// under "user"). An ID without `*` is always registered as an exact match,
+// regardless of mode.
+func (config *Config) Mode(mode uint8) *Config {
+    config.mode = mode
+
+    return config
+}
+
 // ShouldAsync sets whether a dispatcher should execute listeners one after the other
 // or execute them all in goroutines.
 func (config *Config) ShouldAsync(shouldAsync bool) *Config {
@@ -52,6 +95,46 @@ func (config *Config) ShouldWait(shouldWait bool, waitGroup *sync.WaitGroup) err
     return nil
 }
 
+// OnListenerError configures a hook invoked with the failing EventId and error
+// whenever a ListenerFunc listener run under Dispatcher.DispatchContext returns
+// a non-nil error while the dispatcher is async. In sync mode, ListenerFunc
+// errors are returned from DispatchContext instead and don't reach this hook.
+// In any mode, the hook also receives the TypeMismatchError of a listener
+// registered through On, and, under PolicyError, the QueueFullError of an event
+// dispatched to a full consume-mode queue.
+func (config *Config) OnListenerError(hook func(EventId, error)) *Config {
+    config.onListenerError = hook
+
+    return config
+}
+
+// ShouldConsume switches the dispatcher to consume mode: instead of running
+// listeners on the goroutine that called Dispatch (synchronously or through
+// the legacy one-goroutine-per-dispatch async mode), NewDispatcher starts a
+// pool of workers goroutines that drain a buffered channel of bufferSize
+// capacity. Dispatch then only enqueues the event and returns immediately;
+// Dispatcher.Close() closes the channel and waits for the workers to drain
+// it, replacing the *sync.WaitGroup.Wait() contract of the other modes. See
+// Config.OnQueueFull for what happens when the queue is full.
+func (config *Config) ShouldConsume(workers int, bufferSize int) *Config {
+    config.isConsuming = true
+    config.workers = workers
+    config.bufferSize = bufferSize
+
+    return config
+}
+
+// OnQueueFull picks what a consume-mode dispatcher does with an event
+// dispatched while its queue is already full of bufferSize events.
+// PolicyBlock (the default) blocks Dispatch until a worker frees up room.
+// PolicyDrop silently drops the event. PolicyError reports a QueueFullError
+// to Config.OnListenerError instead of blocking.
+func (config *Config) OnQueueFull(policy QueueFullPolicy) *Config {
+    config.queueFullPolicy = policy
+
+    return config
+}
+
 // AsyncConfigError can be found when there is an error setting up a dispatcher.
 type AsyncConfigError struct {
     message string
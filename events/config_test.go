@@ -65,3 +65,58 @@ func TestTogglesWaiting(test *testing.T) {
         test.Fatal("`config.shouldWait` should be `false` and `config.waitGroup` shouldn't be `nil`")
     }
 }
+
+func TestSetsMode(test *testing.T) {
+    config := newDefaultConfig()
+
+    config.Mode(ModePath)
+    if config.mode != ModePath {
+        test.Fatal("`config.mode` should be `ModePath`")
+    }
+
+    config.Mode(ModeSimple)
+    if config.mode != ModeSimple {
+        test.Fatal("`config.mode` should be `ModeSimple`")
+    }
+}
+
+func TestSetsOnListenerError(test *testing.T) {
+    config := newDefaultConfig()
+    called := false
+
+    config.OnListenerError(func(_ EventId, _ error) { called = true })
+
+    config.onListenerError(EventA, nil)
+    if !called {
+        test.Fatal("`config.onListenerError` should have been set to the provided hook")
+    }
+}
+
+func TestSetsShouldConsume(test *testing.T) {
+    config := newDefaultConfig()
+
+    config.ShouldConsume(4, 100)
+    if !config.isConsuming {
+        test.Fatal("`config.isConsuming` should be `true`")
+    }
+    if config.workers != 4 {
+        test.Fatal("`config.workers` should be `4`")
+    }
+    if config.bufferSize != 100 {
+        test.Fatal("`config.bufferSize` should be `100`")
+    }
+}
+
+func TestSetsOnQueueFull(test *testing.T) {
+    config := newDefaultConfig()
+
+    config.OnQueueFull(PolicyDrop)
+    if config.queueFullPolicy != PolicyDrop {
+        test.Fatal("`config.queueFullPolicy` should be `PolicyDrop`")
+    }
+
+    config.OnQueueFull(PolicyError)
+    if config.queueFullPolicy != PolicyError {
+        test.Fatal("`config.queueFullPolicy` should be `PolicyError`")
+    }
+}
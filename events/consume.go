@@ -0,0 +1,85 @@
+package events
+
+import (
+    "context"
+    "fmt"
+)
+
+// startConsuming allocates dispatcher's queue and launches the worker pool
+// configured through Config.ShouldConsume. It is a no-op if the dispatcher
+// isn't in consume mode.
+func (dispatcher *Dispatcher) startConsuming() {
+    if !dispatcher.config.isConsuming {
+        return
+    }
+
+    dispatcher.queue = make(chan Event, dispatcher.config.bufferSize)
+    dispatcher.queueWorkers.Add(dispatcher.config.workers)
+
+    for i := 0; i < dispatcher.config.workers; i++ {
+        go dispatcher.consume()
+    }
+}
+
+// consume drains dispatcher.queue, fully processing each event, until the
+// queue is closed.
+func (dispatcher *Dispatcher) consume() {
+    defer dispatcher.queueWorkers.Done()
+
+    for event := range dispatcher.queue {
+        dispatcher.DispatchContext(context.Background(), event)
+    }
+}
+
+// enqueue hands event to the worker pool, honoring the dispatcher's
+// Config.OnQueueFull policy when the queue is already full.
+func (dispatcher *Dispatcher) enqueue(event Event) {
+    switch dispatcher.config.queueFullPolicy {
+    case PolicyDrop:
+        select {
+        case dispatcher.queue <- event:
+        default:
+        }
+    case PolicyError:
+        select {
+        case dispatcher.queue <- event:
+        default:
+            if dispatcher.config.onListenerError != nil {
+                dispatcher.config.onListenerError(event.id, newQueueFullError(event.id))
+            }
+        }
+    default:
+        dispatcher.queue <- event
+    }
+}
+
+// Close closes dispatcher's queue and blocks until every worker has drained
+// it. Only meaningful for a dispatcher built with Config.ShouldConsume; it is
+// a no-op otherwise. A closed dispatcher cannot be dispatched to again.
+func (dispatcher *Dispatcher) Close() {
+    if !dispatcher.config.isConsuming {
+        return
+    }
+
+    dispatcher.closeOnce.Do(func() {
+        close(dispatcher.queue)
+    })
+
+    dispatcher.queueWorkers.Wait()
+}
+
+// QueueFullError can be found when Config.OnQueueFull is set to PolicyError
+// and an event is dispatched while the consume-mode queue is already full.
+type QueueFullError struct {
+    message string
+}
+
+// newQueueFullError creates a new QueueFullError.
+func newQueueFullError(id EventId) error {
+    return &QueueFullError{fmt.Sprintf("events: queue is full, event %q was dropped", id)}
+}
+
+// Error returns the error message.
+func (err *QueueFullError) Error() string {
+    return err.message
+}
@@ -0,0 +1,99 @@
+package events
+
+import (
+    "sync"
+    "testing"
+    "time"
+)
+
+func TestConsumeModeProcessesEventsOnWorkers(test *testing.T) {
+    var mutex sync.Mutex
+    seen := make(map[int]bool)
+
+    dispatcher := NewDispatcher(func(config *Config) {
+        config.ShouldConsume(2, 4)
+    })
+    dispatcher.Register(EventA, func(event Event) {
+        mutex.Lock()
+        seen[event.Get().(int)] = true
+        mutex.Unlock()
+    })
+
+    for i := 0; i < 4; i++ {
+        dispatcher.Dispatch(Make(EventA, i))
+    }
+
+    dispatcher.Close()
+
+    for i := 0; i < 4; i++ {
+        if !seen[i] {
+            test.Fatalf("Event %d should have been processed before Close returned", i)
+        }
+    }
+}
+
+func TestOnQueueFullDropsEvents(test *testing.T) {
+    release := make(chan struct{})
+    started := make(chan struct{}, 1)
+
+    dispatcher := NewDispatcher(func(config *Config) {
+        config.ShouldConsume(1, 1)
+        config.OnQueueFull(PolicyDrop)
+    })
+    dispatcher.Register(EventA, func(_ Event) {
+        select {
+        case started <- struct{}{}:
+        default:
+        }
+        <-release
+    })
+
+    dispatcher.Dispatch(Make(EventA, 1)) // picked up by the single worker, blocks on release
+    <-started
+    dispatcher.Dispatch(Make(EventA, 2)) // fills the buffer of 1
+    dispatcher.Dispatch(Make(EventA, 3)) // queue is full, should be dropped instead of blocking
+
+    close(release)
+    dispatcher.Close()
+}
+
+func TestOnQueueFullReportsAnError(test *testing.T) {
+    reported := make(chan error, 1)
+    release := make(chan struct{})
+    started := make(chan struct{}, 1)
+
+    dispatcher := NewDispatcher(func(config *Config) {
+        config.ShouldConsume(1, 1)
+        config.OnQueueFull(PolicyError)
+        config.OnListenerError(func(_ EventId, err error) {
+            select {
+            case reported <- err:
+            default:
+            }
+        })
+    })
+    dispatcher.Register(EventA, func(_ Event) {
+        select {
+        case started <- struct{}{}:
+        default:
+        }
+        <-release
+    })
+
+    dispatcher.Dispatch(Make(EventA, 1))
+    <-started
+    dispatcher.Dispatch(Make(EventA, 2))
+    dispatcher.Dispatch(Make(EventA, 3))
+
+    select {
+    case err := <-reported:
+        if _, ok := err.(*QueueFullError); !ok {
+            test.Fatal("A QueueFullError should have been reported")
+        }
+    case <-time.After(time.Second):
+        test.Fatal("Config.OnListenerError should have been called")
+    }
+
+    close(release)
+    dispatcher.Close()
+}
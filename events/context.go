@@ -0,0 +1,125 @@
+package events
+
+import (
+    "context"
+    "errors"
+    "sync"
+)
+
+// ListenerFunc is a context-aware listener that reports failures back to the
+// dispatcher instead of having to handle them on its own. See
+// Dispatcher.RegisterFunc and Dispatcher.DispatchContext.
+type ListenerFunc func(ctx context.Context, event Event) error
+
+// RegisterFunc registers a context-aware listener under id. Unlike Register,
+// an error returned by fn is collected instead of having to be handled by fn
+// itself: DispatchContext aggregates it in sync mode and Config.OnListenerError
+// receives it in async mode. Just like Register, an id containing a `*` is
+// registered as a pattern; see RegisterFuncWithPriority to control the order
+// fn runs in relative to others registered under the same id, and OnceFunc
+// to have fn cancel itself after its first invocation.
+func (dispatcher *Dispatcher) RegisterFunc(id EventId, fn ListenerFunc) Subscription {
+    return dispatcher.addSubscription(id, &subscription{id: nextSubscriptionId(), fn: fn})
+}
+
+// RegisterFuncWithPriority works the same as RegisterFunc but runs fn before
+// every other listener registered under id with a lower priority, regardless
+// of registration order; see Dispatcher.RegisterWithPriority.
+func (dispatcher *Dispatcher) RegisterFuncWithPriority(id EventId, fn ListenerFunc, priority int) Subscription {
+    return dispatcher.addSubscription(id, &subscription{id: nextSubscriptionId(), fn: fn, priority: priority})
+}
+
+// OnceFunc works like RegisterFunc but fn is cancelled right after its first
+// invocation; see Dispatcher.Once.
+func (dispatcher *Dispatcher) OnceFunc(id EventId, fn ListenerFunc) Subscription {
+    sub := &subscription{id: nextSubscriptionId()}
+    fire := new(sync.Once)
+    var fireErr error
+
+    sub.fn = func(ctx context.Context, event Event) error {
+        fire.Do(func() {
+            fireErr = fn(ctx, event)
+            sub.Cancel()
+        })
+
+        return fireErr
+    }
+
+    return dispatcher.addSubscription(id, sub)
+}
+
+// DispatchContext works the same as Dispatch but threads ctx into every
+// listener registered through RegisterFunc or OnceFunc, and aggregates any
+// error they return (sync mode) into the returned error using errors.Join.
+// In async mode, listener errors are instead delivered to Config.OnListenerError
+// and a cancelled ctx abandons any listener not yet started. Unlike Dispatch,
+// DispatchContext never enqueues: on a dispatcher built with
+// Config.ShouldConsume it runs the listeners on the calling goroutine, which is
+// how the consume-mode workers process queued events.
+func (dispatcher *Dispatcher) DispatchContext(ctx context.Context, event Event) (*Dispatcher, DispatchResult, error) {
+    result := DispatchResult{}
+
+    // Each call gets its own propagation state, so a StopPropagation from an
+    // earlier dispatch of the same event doesn't carry over to this one.
+    event.state = &dispatchState{}
+
+    dispatcher.mu.Lock()
+    listeners, hasListeners := dispatcher.listeners[event.id]
+    patterns := dispatcher.patterns
+    dispatcher.mu.Unlock()
+
+    var err error
+
+    if hasListeners {
+        var handled int
+        handled, err = dispatcher.run(ctx, event, listeners)
+        result.Handled += handled
+
+        dispatcher.pruneListeners(event.id)
+    }
+
+    if !event.IsPropagationStopped() && ctx.Err() == nil {
+        for _, pattern := range patterns {
+            if event.IsPropagationStopped() || ctx.Err() != nil {
+                break
+            }
+            if pattern.cancelled.Load() || !pattern.matches(event.id) {
+                continue
+            }
+
+            handled, patternErr := dispatcher.run(ctx, event, []*subscription{pattern})
+            result.Handled += handled
+            err = errors.Join(err, patternErr)
+        }
+
+        dispatcher.prunePatterns()
+    }
+
+    result.Stopped = event.IsPropagationStopped()
+
+    if result.Handled > 0 && dispatcher.config.shouldWait {
+        dispatcher.waitGroup.Wait()
+    }
+
+    return dispatcher, result, err
+}
+
+// RegisterFunc works the same as Dispatcher.RegisterFunc() but panics if no
+// facade is configured.
+func RegisterFunc(id EventId, fn ListenerFunc) Subscription {
+    if facade == nil {
+        panic("No facade registered")
+    }
+
+    return facade.RegisterFunc(id, fn)
+}
+
+// DispatchContext works the same as Dispatcher.DispatchContext() but panics if
+// no facade is configured.
+func DispatchContext(ctx context.Context, event Event) (*Dispatcher, DispatchResult, error) {
+    if facade == nil {
+        panic("No facade registered")
+    }
+
+    return facade.DispatchContext(ctx, event)
+}
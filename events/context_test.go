@@ -0,0 +1,177 @@
+package events
+
+import (
+    "context"
+    "errors"
+    "testing"
+)
+
+type contextKey string
+
+func TestRegistersAndDispatchesContextListeners(test *testing.T) {
+    dispatcher := NewDispatcher()
+    var received context.Context
+
+    dispatcher.RegisterFunc(EventA, func(ctx context.Context, event Event) error {
+        received = ctx
+        return nil
+    })
+
+    ctx := context.WithValue(context.Background(), contextKey("key"), "value")
+    _, result, err := dispatcher.DispatchContext(ctx, Make(EventA, nil))
+
+    if err != nil {
+        test.Fatal("There shouldn't be an error when every listener succeeds")
+    }
+    if result.Handled != 1 {
+        test.Fatal("The context listener should have been reported as handled")
+    }
+    if received != ctx {
+        test.Fatal("The listener should have received the context passed to DispatchContext")
+    }
+}
+
+func TestAggregatesSyncListenerErrors(test *testing.T) {
+    dispatcher := NewDispatcher()
+    errA := errors.New("listener a failed")
+    errB := errors.New("listener b failed")
+
+    dispatcher.RegisterFunc(EventA, func(_ context.Context, _ Event) error { return errA })
+    dispatcher.RegisterFunc(EventA, func(_ context.Context, _ Event) error { return errB })
+
+    _, _, err := dispatcher.DispatchContext(context.Background(), Make(EventA, nil))
+
+    if !errors.Is(err, errA) || !errors.Is(err, errB) {
+        test.Fatal("The aggregated error should wrap both listener errors")
+    }
+}
+
+func TestReportsAsyncListenerErrorsToTheHook(test *testing.T) {
+    reported := make(chan error, 1)
+    failure := errors.New("listener failed")
+
+    dispatcher := NewDispatcher(func(config *Config) {
+        config.ShouldAsync(true)
+        config.OnListenerError(func(_ EventId, err error) {
+            reported <- err
+        })
+    })
+    dispatcher.RegisterFunc(EventA, func(_ context.Context, _ Event) error { return failure })
+
+    dispatcher.DispatchContext(context.Background(), Make(EventA, nil))
+
+    if err := <-reported; !errors.Is(err, failure) {
+        test.Fatal("The hook should have received the listener's error")
+    }
+}
+
+func TestRegistrationsSurviveAnInFlightDispatch(test *testing.T) {
+    dispatcher := NewDispatcher()
+    started := make(chan struct{})
+    release := make(chan struct{})
+
+    dispatcher.Register(EventA, func(_ Event) {
+        close(started)
+        <-release
+    })
+
+    done := make(chan struct{})
+    go func() {
+        dispatcher.Dispatch(Make(EventA, nil))
+        close(done)
+    }()
+
+    <-started
+    dispatcher.Register(EventA, func(_ Event) {})
+    close(release)
+    <-done
+
+    if len(dispatcher.listeners[EventA]) != 2 {
+        test.Fatal("A listener registered while a dispatch was in flight shouldn't be lost")
+    }
+}
+
+func TestDispatchesToPatternRegisteredContextListeners(test *testing.T) {
+    dispatcher := NewDispatcher()
+    triggered := false
+
+    dispatcher.RegisterFunc("user.*", func(_ context.Context, _ Event) error {
+        triggered = true
+        return nil
+    })
+
+    dispatcher.Dispatch(Make("user.created", nil))
+
+    if !triggered {
+        test.Fatal("A context listener registered under a pattern should run for a matching id")
+    }
+}
+
+func TestRegisterFuncWithPriorityRunsBeforeLowerPriorityListeners(test *testing.T) {
+    dispatcher := NewDispatcher()
+    var order []string
+
+    dispatcher.Register(EventA, func(_ Event) { order = append(order, "default") })
+    dispatcher.RegisterFuncWithPriority(EventA, func(_ context.Context, _ Event) error {
+        order = append(order, "high")
+        return nil
+    }, 1)
+
+    dispatcher.Dispatch(Make(EventA, nil))
+
+    if len(order) != 2 || order[0] != "high" || order[1] != "default" {
+        test.Fatal("The higher priority context listener should have run first")
+    }
+}
+
+func TestOnceFuncFiresOnlyOnce(test *testing.T) {
+    dispatcher := NewDispatcher()
+    calls := 0
+
+    dispatcher.OnceFunc(EventA, func(_ context.Context, _ Event) error {
+        calls++
+        return nil
+    })
+
+    dispatcher.Dispatch(Make(EventA, nil))
+    dispatcher.Dispatch(Make(EventA, nil))
+
+    if calls != 1 {
+        test.Fatal("An OnceFunc listener should only be invoked once")
+    }
+}
+
+func TestOffRemovesContextListeners(test *testing.T) {
+    dispatcher := NewDispatcher()
+    triggered := false
+
+    dispatcher.RegisterFunc(EventA, func(_ context.Context, _ Event) error {
+        triggered = true
+        return nil
+    })
+    dispatcher.Off(EventA)
+
+    dispatcher.Dispatch(Make(EventA, nil))
+
+    if triggered {
+        test.Fatal("Context listeners removed with Off shouldn't run")
+    }
+}
+
+func TestKeepsBareListenersWorkingAlongsideContextListeners(test *testing.T) {
+    dispatcher := NewDispatcher()
+    bareRan := false
+    ctxRan := false
+
+    dispatcher.Register(EventA, func(_ Event) { bareRan = true })
+    dispatcher.RegisterFunc(EventA, func(_ context.Context, _ Event) error {
+        ctxRan = true
+        return nil
+    })
+
+    dispatcher.Dispatch(Make(EventA, nil))
+
+    if !bareRan || !ctxRan {
+        test.Fatal("Both the bare and the context listener should have run")
+    }
+}
@@ -98,4 +98,100 @@
 // Failing to provide a sync.WaitGroup instance will result in an error of type
 // events.AsyncConfigError. On this mode the caller is responsible for managing the WaitGroup
 // to avoid the main goroutine finishing before the listeners are done.
+//
+// # Patterns
+//
+// An id containing a `*` is registered as a pattern instead of an exact match; it
+// is matched against the id of every event dispatched, in addition to any exact
+// match:
+//
+//	dispatcher.Register("user.*", listener)
+//	dispatcher.Dispatch(events.Make("user.created", nil)) // listener runs
+//
+// Config.Mode picks how `*` is interpreted: ModeSimple (the default) treats a
+// trailing `*` as matching anything to the end of the id, while ModePath treats
+// the id as a dotted path where `*` matches a single segment and `**` matches any
+// remaining segments.
+//
+// # Propagation control
+//
+// A listener can call event.StopPropagation() to prevent any remaining listener
+// from being invoked for the current Dispatch call. The DispatchResult returned
+// by Dispatch/DispatchContext reports how many listeners ran and whether
+// propagation was stopped.
+//
+// # Context-aware listeners
+//
+// RegisterFunc registers a ListenerFunc, a listener that receives a
+// context.Context and can return an error instead of having to handle it on its
+// own:
+//
+//	dispatcher.RegisterFunc(SomeEventId, func(ctx context.Context, event events.Event) error {
+//	    return doSomething(ctx, event.Get())
+//	})
+//
+// DispatchContext works like Dispatch but threads a context.Context into every
+// ListenerFunc and aggregates any error they return using errors.Join. In async
+// mode, listener errors are instead delivered to Config.OnListenerError. Unlike
+// Dispatch, DispatchContext never enqueues: in consume mode it runs the
+// listeners on the calling goroutine. RegisterFunc listeners share the same registry as Register: an id
+// containing `*` is registered as a pattern, and RegisterFuncWithPriority and
+// OnceFunc work the same as RegisterWithPriority and Once.
+//
+// # Subscriptions and unregistering
+//
+// Subscribe works like Register but returns a Subscription that can later be
+// used to cancel the listener, either directly through Subscription.Cancel or in
+// bulk through Dispatcher.Off (by id) or Dispatcher.RemoveAll (everything). Once
+// registers a listener that is cancelled right after its first invocation:
+//
+//	subscription := dispatcher.Subscribe(SomeEventId, listener)
+//	subscription.Cancel()
+//
+//	dispatcher.Once(SomeEventId, listener) // runs at most once
+//
+// # Typed listeners
+//
+// The generic On, MustOn and Emit functions remove the event.Get().(*T) assertion
+// boilerplate: the listener only runs when the dispatched payload is of type T, a
+// mismatch is reported to Config.OnListenerError instead (or a panic, with
+// MustOn):
+//
+//	events.On(dispatcher, SomeEventId, func(data SomeType) {
+//	    // data is already asserted to be a SomeType
+//	})
+//	events.Emit(dispatcher, SomeEventId, someData)
+//
+// TypedDispatcher binds a single event ID to a single payload type so callers
+// don't have to repeat either at every call site:
+//
+//	typed := events.NewTypedDispatcher[SomeType](dispatcher, SomeEventId)
+//	typed.On(func(data SomeType) { /* ... */ })
+//	typed.Emit(someData)
+//
+// # Consume mode
+//
+// Config.ShouldConsume switches a dispatcher to consume mode: instead of running
+// listeners on the goroutine that called Dispatch, a pool of worker goroutines
+// drains a buffered queue. Dispatch only enqueues the event and returns
+// immediately; Dispatcher.Close closes the queue and waits for the workers to
+// drain it:
+//
+//	dispatcher := events.NewDispatcher(func(config *events.Config) {
+//	    config.ShouldConsume(4, 100)
+//	})
+//	defer dispatcher.Close()
+//
+// Config.OnQueueFull picks what happens when Dispatch is called while the queue
+// is already full: block (the default), drop the event, or report a
+// QueueFullError to Config.OnListenerError.
+//
+// # Listener priority
+//
+// RegisterWithPriority works like Subscribe but runs the listener before every
+// other listener registered under the same id with a lower priority, regardless
+// of registration order; listeners sharing the same priority keep running in
+// registration order. Combined with event.StopPropagation(), a high-priority
+// listener (e.g. a validator or an auth gate) can veto dispatch to the
+// lower-priority ones.
 package events
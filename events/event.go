@@ -1,18 +1,42 @@
 package events
 
+import "sync/atomic"
+
 type EventId string
 
 type Event struct {
-    id   EventId
-    data any
+    id    EventId
+    data  any
+    state *dispatchState
+}
+
+// dispatchState is kept behind a pointer so Event can stay a value type
+// while still letting listeners mutate propagation state shared across
+// every listener invoked for a single Dispatch call. stopped is an
+// atomic.Bool because StopPropagation may be called from any listener,
+// including ones running concurrently under async mode.
+type dispatchState struct {
+    stopped atomic.Bool
 }
 
 func Make(id EventId, data any) Event {
-    return Event{id, data}
+    return Event{id: id, data: data, state: &dispatchState{}}
 }
 
 func (event Event) Get() any {
     return event.data
 }
 
+// StopPropagation prevents any remaining listener from being invoked for the
+// current Dispatch call.
+func (event Event) StopPropagation() {
+    event.state.stopped.Store(true)
+}
+
+// IsPropagationStopped reports whether a listener already called
+// event.StopPropagation() for the current Dispatch call.
+func (event Event) IsPropagationStopped() bool {
+    return event.state.stopped.Load()
+}
+
 type Listener func(Event)
@@ -1,106 +1,11 @@
-// Package events provides an observer abstraction that allows functions
-// to be run when certain events are fired from any part of a codebase.
-//
-// # Usage
-//
-// Create a new event dispatcher:
-//
-//  dispatcher := events.NewDispatcher()
-//
-// Register event IDs and listeners:
-//
-//  const SomeEventId events.EventId = "Some description"
-//
-//  listener := func(event events.Event) {
-//      eventData := event.Get().(*SomeType)
-//
-//      // Do something with eventData
-//  }
-//  dispatcher.Register(SomeEventId, listener)
-//
-// It is possible to register multiple listeners for the same event ID.
-// A listener is just a function that receives an events.Event and returns
-// nothing. Inside the listener a type assertion is needed due to
-// events.Event.Get() returning any. eventData will have anything that
-// was passed to the dispatcher when triggering the event.
-//
-// Then one can dispatch an event using:
-//
-//  event := events.Make(SomeEventId, &someData)
-//  dispatcher.Dispatch(event)
-//
-// Listeners will be executed one after the other in the registred order
-// (or with goroutines if configured that way). someData will be available
-// under the events.Event passed to the listener.
-//
-// # Usage as facade
-//
-// Although not recomended, a dispatcher can also be available in the package
-// as facade. Registering listeners and dispatchers work the same but they are
-// available package wide; useful for quick prototypes to avoid passing the
-// dispatcher too deeply the call chain:
-//
-//  package one
-//
-//  import "github.com/jProgr/events"
-//
-//  func f() {
-//      events.NewDispatcher(func(config *events.Config) {
-//          // This makes this dispatcher to be stored in
-//          // the package and makes it available by just
-//          // importing events.
-//          config.AsFacade(true)
-//      })
-//
-//      // Register directly on the package, without calling
-//      // the dispatcher.
-//      events.Register(SomeEventId, someListener)
-//  }
-//
-//  package two
-//
-//  import "github.com/jProgr/events"
-//
-//  func g() {
-//      // Dispatch directly by just importing the package
-//      events.Dispatch(events.Make(SomeEventId, &someData))
-//  }
-//
-// In the example, f() should be run before g() for everything to work. Calling
-// Register() or Dispatch() directly on the package without configuring a dispatcher
-// to work as facade will raise a panic().
-//
-// # Async execution
-//
-// The default execution order of listeners is just one after the other in the goroutine
-// where the event was triggered (usually the main one). If the listeners are to do slow
-// work (usually network stuff), one can configure the dispatcher to run each listener in
-// its own goroutine. There are two modes:
-//
-//   - Trigger the event and wait for every listener to finish work before continuing.
-//   - Trigger the event, launch the listeners and continue work without waiting on the
-//     goroutines.
-//
-// To wait on every goroutine to finish:
-//
-//  dispatcher := events.NewDispatcher(func(config *events.Config) {
-//      config.ShouldAsync(true)
-//  })
-//
-// To continue immediately after triggering an event:
-//
-//  waitGroup := new(sync.WaitGroup)
-//  dispatcher := events.NewDispatcher(func(config *events.Config) {
-//      config.ShouldAsync(true)
-//      config.ShouldWait(false, waitGroup)
-//  })
-//
-// Failing to provide a sync.WaitGroup instance will result in an error of type
-// events.AsyncConfigError. On this mode the caller is responsible for managing the WaitGroup
-// to avoid the main goroutine finishing before the listeners are done.
 package events
 
-import "sync"
+import (
+    "context"
+    "errors"
+    "sort"
+    "sync"
+)
 
 // facade works as global variable when using a dispatcher as facade.
 var facade *Dispatcher
@@ -108,9 +13,14 @@ var facade *Dispatcher
 // Dispatcher stores the map of event IDs and their listeners, config, registers new
 // listeners and dispatchs events. Main interactor of the package.
 type Dispatcher struct {
-    listeners map[EventId][]Listener
-    config    *Config
-    waitGroup *sync.WaitGroup
+    listeners    map[EventId][]*subscription
+    patterns     []*subscription
+    config       *Config
+    waitGroup    *sync.WaitGroup
+    mu           sync.Mutex
+    queue        chan Event
+    queueWorkers sync.WaitGroup
+    closeOnce    sync.Once
 }
 
 // NewDispatcher setups and creates a new Dispatcher. A default dispatcher:
@@ -146,7 +56,7 @@ func NewDispatcher(configurers ...func(*Config)) *Dispatcher {
     }
 
     dispatcher := &Dispatcher{
-        listeners: make(map[EventId][]Listener),
+        listeners: make(map[EventId][]*subscription),
         config:    config,
         waitGroup: waitGroup,
     }
@@ -155,56 +65,254 @@ func NewDispatcher(configurers ...func(*Config)) *Dispatcher {
         facade = dispatcher
     }
 
+    dispatcher.startConsuming()
+
     return dispatcher
 }
 
 // Register adds to the internal map of event IDs and listeners the arguments provided.
+// An id containing a `*` is registered as a pattern instead of an exact match; see
+// Config.Mode for how patterns are interpreted. Use Subscribe instead if the
+// returned Subscription is needed to later cancel the listener, or
+// RegisterWithPriority to control the order listener runs in relative to
+// others registered under the same id.
 func (dispatcher *Dispatcher) Register(id EventId, listener Listener) *Dispatcher {
-    if listeners, ok := dispatcher.listeners[id]; ok {
-        dispatcher.listeners[id] = append(listeners, listener)
-        return dispatcher
+    dispatcher.Subscribe(id, listener)
+
+    return dispatcher
+}
+
+// Subscribe works the same as Register but returns a Subscription that can
+// later be used to cancel the listener, either directly through
+// Subscription.Cancel or in bulk through Dispatcher.Off/Dispatcher.RemoveAll.
+func (dispatcher *Dispatcher) Subscribe(id EventId, listener Listener) Subscription {
+    return dispatcher.addSubscription(id, &subscription{id: nextSubscriptionId(), listener: listener})
+}
+
+// RegisterWithPriority works the same as Subscribe but runs listener before
+// every other listener registered under id with a lower priority, regardless
+// of registration order; listeners sharing the same priority keep running in
+// registration order. The default priority used by Register and Subscribe is
+// 0. Combined with event.StopPropagation(), a high-priority listener (e.g. a
+// validator or an auth gate) can veto dispatch to the lower-priority ones.
+func (dispatcher *Dispatcher) RegisterWithPriority(id EventId, listener Listener, priority int) Subscription {
+    return dispatcher.addSubscription(id, &subscription{id: nextSubscriptionId(), listener: listener, priority: priority})
+}
+
+// Once works like Subscribe but the listener is cancelled right after its
+// first invocation. A sync.Once per subscription guarantees it fires exactly
+// once even when several goroutines dispatch matching events concurrently.
+func (dispatcher *Dispatcher) Once(id EventId, listener Listener) Subscription {
+    sub := &subscription{id: nextSubscriptionId()}
+    fire := new(sync.Once)
+
+    sub.listener = func(event Event) {
+        fire.Do(func() {
+            listener(event)
+            sub.Cancel()
+        })
     }
 
-    dispatcher.listeners[id] = []Listener{listener}
+    return dispatcher.addSubscription(id, sub)
+}
+
+// addSubscription stores sub as an exact match under id, or compiles and
+// stores it as a pattern when id contains pattern characters, keeping the
+// destination slice sorted by descending priority.
+func (dispatcher *Dispatcher) addSubscription(id EventId, sub *subscription) Subscription {
+    dispatcher.mu.Lock()
+    defer dispatcher.mu.Unlock()
+
+    sub.registeredId = id
+
+    if isPattern(id) {
+        sub.matches = compilePattern(id, dispatcher.config.mode)
+        dispatcher.patterns = append(dispatcher.patterns, sub)
+        sortByPriority(dispatcher.patterns)
+
+        return sub
+    }
+
+    dispatcher.listeners[id] = append(dispatcher.listeners[id], sub)
+    sortByPriority(dispatcher.listeners[id])
+
+    return sub
+}
+
+// sortByPriority sorts subs by descending priority, keeping subs of equal
+// priority in their relative (registration) order.
+func sortByPriority(subs []*subscription) {
+    sort.SliceStable(subs, func(i, j int) bool {
+        return subs[i].priority > subs[j].priority
+    })
+}
+
+// Off removes every listener registered under id, whether added through
+// Register, Subscribe, Once, RegisterFunc or OnceFunc. Patterns registered
+// under id are removed as well; patterns registered under a different id
+// that happen to match id are left untouched.
+func (dispatcher *Dispatcher) Off(id EventId) *Dispatcher {
+    dispatcher.mu.Lock()
+    defer dispatcher.mu.Unlock()
+
+    delete(dispatcher.listeners, id)
+    dispatcher.patterns = removeRegisteredUnder(dispatcher.patterns, id)
+
+    return dispatcher
+}
+
+// RemoveAll removes every listener registered on the dispatcher: exact
+// matches and patterns alike.
+func (dispatcher *Dispatcher) RemoveAll() *Dispatcher {
+    dispatcher.mu.Lock()
+    defer dispatcher.mu.Unlock()
+
+    dispatcher.listeners = make(map[EventId][]*subscription)
+    dispatcher.patterns = nil
 
     return dispatcher
 }
 
+// DispatchResult reports what happened during a Dispatch call: how many
+// listeners were actually invoked and whether one of them called
+// event.StopPropagation() to cut the dispatch short.
+type DispatchResult struct {
+    Handled int
+    Stopped bool
+}
+
 // Dispatch calls all the listeners registered under the event ID of the argument
-// provided and passes it to them. If the event ID is not registered, nothing is done.
-func (dispatcher *Dispatcher) Dispatch(event Event) *Dispatcher {
-    listeners, ok := dispatcher.listeners[event.id]
-    if !ok || len(listeners) == 0 {
-        return dispatcher
+// provided and passes it to them, followed by the listeners registered under a
+// matching pattern. Each of the two groups runs in descending priority and, among
+// equal priorities, in registration order. If nothing matches, nothing is done.
+// A listener may call event.StopPropagation() to prevent any remaining listener
+// from being invoked; the returned DispatchResult reports how many listeners ran
+// and whether propagation was stopped. See DispatchContext for a variant that
+// threads a context.Context into every listener and surfaces listener errors.
+// On a dispatcher built with Config.ShouldConsume, Dispatch only enqueues
+// event and returns immediately with a zero DispatchResult; see Dispatcher.Close.
+func (dispatcher *Dispatcher) Dispatch(event Event) (*Dispatcher, DispatchResult) {
+    if dispatcher.config.isConsuming {
+        dispatcher.enqueue(event)
+
+        return dispatcher, DispatchResult{}
     }
 
-    for _, listener := range listeners {
+    _, result, _ := dispatcher.DispatchContext(context.Background(), event)
+
+    return dispatcher, result
+}
+
+// run executes subs against event and ctx, one after the other or under
+// goroutines depending on the dispatcher's async configuration, skipping
+// cancelled subscriptions and stopping early if a listener called
+// event.StopPropagation() or ctx was cancelled. It returns how many
+// listeners were invoked and, in sync mode, the errors.Join of every
+// non-nil error returned by a subscription registered through RegisterFunc
+// or OnceFunc (a bare Listener never produces an error). In async mode, no
+// further goroutine is launched once propagation is stopped or ctx is
+// cancelled, but the ones already running are left to finish.
+func (dispatcher *Dispatcher) run(ctx context.Context, event Event, subs []*subscription) (int, error) {
+    handled := 0
+    var errs []error
+
+    for _, sub := range subs {
+        if event.IsPropagationStopped() || ctx.Err() != nil {
+            break
+        }
+        if sub.cancelled.Load() {
+            continue
+        }
+
         if dispatcher.config.isAsync {
-            dispatchAsync(dispatcher, event, listener)
+            dispatcher.runAsync(ctx, event, sub)
+            handled++
             continue
         }
 
-        listener(event)
+        if err := sub.invoke(ctx, event); err != nil {
+            errs = append(errs, err)
+        }
+        handled++
     }
 
-    if dispatcher.config.shouldWait {
-        dispatcher.waitGroup.Wait()
+    if len(errs) == 0 {
+        return handled, nil
     }
 
-    return dispatcher
+    return handled, errors.Join(errs...)
 }
 
-// dispatchAsync executes listener under a goroutine and updates dispatcher.waitGroup
-// accordingly.
-func dispatchAsync(dispatcher *Dispatcher, event Event, listener Listener) {
+// runAsync executes sub under a goroutine, updating dispatcher.waitGroup and
+// reporting any error it returns to Config.OnListenerError.
+func (dispatcher *Dispatcher) runAsync(ctx context.Context, event Event, sub *subscription) {
     dispatcher.waitGroup.Add(1)
 
     go func() {
         defer dispatcher.waitGroup.Done()
-        listener(event)
+
+        if ctx.Err() != nil {
+            return
+        }
+        if err := sub.invoke(ctx, event); err != nil && dispatcher.config.onListenerError != nil {
+            dispatcher.config.onListenerError(event.id, err)
+        }
     }()
 }
 
+// pruneCancelled returns a new slice with every cancelled subscription lazily
+// removed, preserving the relative order of the ones kept.
+func pruneCancelled(subs []*subscription) []*subscription {
+    kept := make([]*subscription, 0, len(subs))
+
+    for _, sub := range subs {
+        if !sub.cancelled.Load() {
+            kept = append(kept, sub)
+        }
+    }
+
+    return kept
+}
+
+// removeRegisteredUnder returns a new slice with every subscription
+// registered under id removed, preserving the relative order of the ones
+// kept.
+func removeRegisteredUnder(subs []*subscription, id EventId) []*subscription {
+    kept := make([]*subscription, 0, len(subs))
+
+    for _, sub := range subs {
+        if sub.registeredId != id {
+            kept = append(kept, sub)
+        }
+    }
+
+    return kept
+}
+
+// pruneListeners removes cancelled subscriptions from
+// dispatcher.listeners[id], reading and writing the current value under the
+// lock so a Register, Subscribe or Off call that landed on id while a
+// dispatch was in flight isn't clobbered by a write-back based on a stale
+// pre-dispatch snapshot.
+func (dispatcher *Dispatcher) pruneListeners(id EventId) {
+    dispatcher.mu.Lock()
+    defer dispatcher.mu.Unlock()
+
+    if current, ok := dispatcher.listeners[id]; ok {
+        dispatcher.listeners[id] = pruneCancelled(current)
+    }
+}
+
+// prunePatterns removes cancelled subscriptions from dispatcher.patterns,
+// reading and writing the current value under the lock for the same reason
+// as pruneListeners.
+func (dispatcher *Dispatcher) prunePatterns() {
+    dispatcher.mu.Lock()
+    defer dispatcher.mu.Unlock()
+
+    dispatcher.patterns = pruneCancelled(dispatcher.patterns)
+}
+
 // Register works the same as Dispatcher.Register() but panics if no facade is configured.
 func Register(id EventId, listener Listener) *Dispatcher {
     if facade == nil {
@@ -215,10 +323,56 @@ func Register(id EventId, listener Listener) *Dispatcher {
 }
 
 // Dispatch works the same as Dispatcher.Dispatch() but panics if no facade is configured.
-func Dispatch(event Event) *Dispatcher {
+func Dispatch(event Event) (*Dispatcher, DispatchResult) {
     if facade == nil {
         panic("No facade registered")
     }
 
     return facade.Dispatch(event)
 }
+
+// Subscribe works the same as Dispatcher.Subscribe() but panics if no facade is configured.
+func Subscribe(id EventId, listener Listener) Subscription {
+    if facade == nil {
+        panic("No facade registered")
+    }
+
+    return facade.Subscribe(id, listener)
+}
+
+// RegisterWithPriority works the same as Dispatcher.RegisterWithPriority() but panics if
+// no facade is configured.
+func RegisterWithPriority(id EventId, listener Listener, priority int) Subscription {
+    if facade == nil {
+        panic("No facade registered")
+    }
+
+    return facade.RegisterWithPriority(id, listener, priority)
+}
+
+// Once works the same as Dispatcher.Once() but panics if no facade is configured.
+func Once(id EventId, listener Listener) Subscription {
+    if facade == nil {
+        panic("No facade registered")
+    }
+
+    return facade.Once(id, listener)
+}
+
+// Off works the same as Dispatcher.Off() but panics if no facade is configured.
+func Off(id EventId) *Dispatcher {
+    if facade == nil {
+        panic("No facade registered")
+    }
+
+    return facade.Off(id)
+}
+
+// RemoveAll works the same as Dispatcher.RemoveAll() but panics if no facade is configured.
+func RemoveAll() *Dispatcher {
+    if facade == nil {
+        panic("No facade registered")
+    }
+
+    return facade.RemoveAll()
+}
@@ -17,11 +17,8 @@ type dto2 struct {
 }
 
 func TestBuildsNewDefaultDispatcher(test *testing.T) {
-    dispatcher, err := NewDispatcher()
+    dispatcher := NewDispatcher()
 
-    if err != nil {
-        test.Fatal("There shouldn't be an error when creating a default dispatcher")
-    }
     if dispatcher.listeners == nil {
         test.Fatal("`dispatcher.listeners` should be a map")
     }
@@ -36,26 +33,20 @@ func TestBuildsNewDefaultDispatcher(test *testing.T) {
 }
 
 func TestBuildsNewAsyncDispatcher(test *testing.T) {
-    dispatcher, err := NewDispatcher(func(config *Config) {
+    dispatcher := NewDispatcher(func(config *Config) {
         config.ShouldAsync(true)
     })
 
-    if err != nil {
-        test.Fatal("An async dispatcher shouldn't produce an error")
-    }
     if !dispatcher.config.isAsync {
         test.Fatal("This dispatcher should be async")
     }
 }
 
 func TestBuildsNewFacadeDispatcher(test *testing.T) {
-    dispatcher, err := NewDispatcher(func(config *Config) {
+    dispatcher := NewDispatcher(func(config *Config) {
         config.AsFacade(true)
     })
 
-    if err != nil {
-        test.Fatal("A facade dispatcher shouldn't produce an error")
-    }
     if !dispatcher.config.isFacade {
         test.Fatal("This dispatcher should be a facade")
     }
@@ -64,22 +55,21 @@ func TestBuildsNewFacadeDispatcher(test *testing.T) {
     }
 }
 
-func TestFailsToBuildADispatcherOnWrongConfig(test *testing.T) {
-    _, err := NewDispatcher(func(config *Config) {
-        config.ShouldWait(false, nil)
+func TestBuildsADispatcherEvenWhenAConfigurerMisconfiguresWaiting(test *testing.T) {
+    dispatcher := NewDispatcher(func(config *Config) {
+        if err := config.ShouldWait(false, nil); err == nil {
+            test.Fatal("`ShouldWait(false, nil)` should return an `AsyncConfigError`")
+        }
     })
 
-    if err == nil {
-        test.Fatal("There should be an error when creating a dispatcher that does not wait and has no sync.WaitGroup")
-    }
-    if _, ok := err.(*AsyncConfigError); !ok {
-        test.Fatal("The error should be of type AsyncConfigError")
+    if !dispatcher.config.shouldWait {
+        test.Fatal("A misconfigured `ShouldWait` call shouldn't change `config.shouldWait`")
     }
 }
 
 func TestRegistersEvents(test *testing.T) {
     listener := func(_ Event) {}
-    dispatcher, _ := NewDispatcher()
+    dispatcher := NewDispatcher()
     dispatcher.Register(EventA, listener)
 
     listeners, ok := dispatcher.listeners[EventA]
@@ -103,7 +93,7 @@ func TestTriggersListeners(test *testing.T) {
         dto := event.Get().(*dto)
         dto.data = dto.data + 1
     }
-    dispatcher, _ := NewDispatcher()
+    dispatcher := NewDispatcher()
     dispatcher.
         Register(EventA, listener).
         Register(EventB, listener)
@@ -130,7 +120,7 @@ func TestTriggersListenersAsync(test *testing.T) {
         dto.listenerB = true
     }
 
-    dispatcher, _ := NewDispatcher(func(config *Config) {
+    dispatcher := NewDispatcher(func(config *Config) {
         config.ShouldAsync(true)
     })
     dispatcher.
@@ -0,0 +1,62 @@
+package events
+
+import "strings"
+
+// isPattern reports whether id contains characters that require pattern
+// matching instead of an exact map lookup.
+func isPattern(id EventId) bool {
+    return strings.Contains(string(id), "*")
+}
+
+// compilePattern builds the matcher function for id according to mode.
+func compilePattern(id EventId, mode uint8) func(EventId) bool {
+    if mode == ModePath {
+        return compilePathPattern(id)
+    }
+
+    return compileSimplePattern(id)
+}
+
+// compileSimplePattern implements ModeSimple: a trailing `*` matches anything
+// to the end of the candidate ID, everything before it must match literally.
+func compileSimplePattern(id EventId) func(EventId) bool {
+    pattern := string(id)
+    prefix, isWildcard := strings.CutSuffix(pattern, "*")
+
+    return func(candidate EventId) bool {
+        if !isWildcard {
+            return string(candidate) == pattern
+        }
+
+        return strings.HasPrefix(string(candidate), prefix)
+    }
+}
+
+// compilePathPattern implements ModePath: id is treated as a dotted path
+// where `*` matches a single segment and `**` matches any remaining
+// segments.
+func compilePathPattern(id EventId) func(EventId) bool {
+    segments := strings.Split(string(id), ".")
+
+    return func(candidate EventId) bool {
+        return matchPathSegments(segments, strings.Split(string(candidate), "."))
+    }
+}
+
+// matchPathSegments compares pattern segments against candidate segments
+// one by one, honoring `*` and `**` wildcards.
+func matchPathSegments(pattern, candidate []string) bool {
+    for i, segment := range pattern {
+        if segment == "**" {
+            return true
+        }
+        if i >= len(candidate) {
+            return false
+        }
+        if segment != "*" && segment != candidate[i] {
+            return false
+        }
+    }
+
+    return len(pattern) == len(candidate)
+}
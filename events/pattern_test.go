@@ -0,0 +1,87 @@
+package events
+
+import "testing"
+
+func TestMatchesSimplePatterns(test *testing.T) {
+    matches := compileSimplePattern("user.*")
+
+    if !matches("user.created") {
+        test.Fatal("`user.*` should match `user.created`")
+    }
+    if !matches("user.profile.updated") {
+        test.Fatal("`user.*` should match `user.profile.updated`")
+    }
+    if matches("order.created") {
+        test.Fatal("`user.*` shouldn't match `order.created`")
+    }
+}
+
+func TestMatchesPathPatterns(test *testing.T) {
+    matches := compilePathPattern("user.*.created")
+
+    if !matches("user.admin.created") {
+        test.Fatal("`user.*.created` should match `user.admin.created`")
+    }
+    if matches("user.admin.profile.created") {
+        test.Fatal("`user.*.created` shouldn't match `user.admin.profile.created`")
+    }
+
+    matchesAll := compilePathPattern("user.**")
+
+    if !matchesAll("user.admin.created") {
+        test.Fatal("`user.**` should match `user.admin.created`")
+    }
+    if !matchesAll("user.admin.profile.created") {
+        test.Fatal("`user.**` should match `user.admin.profile.created`")
+    }
+    if matchesAll("order.created") {
+        test.Fatal("`user.**` shouldn't match `order.created`")
+    }
+}
+
+func TestDetectsPatterns(test *testing.T) {
+    if isPattern("user.created") {
+        test.Fatal("`user.created` shouldn't be detected as a pattern")
+    }
+    if !isPattern("user.*") {
+        test.Fatal("`user.*` should be detected as a pattern")
+    }
+}
+
+func TestDispatchesToPatternListeners(test *testing.T) {
+    dispatcher := NewDispatcher()
+    triggered := 0
+
+    dispatcher.
+        Register(EventA, func(_ Event) { triggered++ }).
+        Register("user.*", func(_ Event) { triggered++ })
+
+    dispatcher.Dispatch(Make("user.created", nil))
+    if triggered != 1 {
+        test.Fatal("Only the pattern listener should have been triggered")
+    }
+
+    dispatcher.Dispatch(Make(EventA, nil))
+    if triggered != 2 {
+        test.Fatal("The exact listener should have been triggered")
+    }
+}
+
+func TestDispatchesToPatternListenersInPathMode(test *testing.T) {
+    dispatcher := NewDispatcher(func(config *Config) {
+        config.Mode(ModePath)
+    })
+    triggered := false
+
+    dispatcher.Register("user.*.created", func(_ Event) { triggered = true })
+
+    dispatcher.Dispatch(Make("user.admin.profile.created", nil))
+    if triggered {
+        test.Fatal("`user.*.created` shouldn't match `user.admin.profile.created`")
+    }
+
+    dispatcher.Dispatch(Make("user.admin.created", nil))
+    if !triggered {
+        test.Fatal("`user.*.created` should match `user.admin.created`")
+    }
+}
@@ -0,0 +1,60 @@
+package events
+
+import "testing"
+
+func TestListenersRunInDescendingPriorityOrder(test *testing.T) {
+    dispatcher := NewDispatcher()
+    var order []string
+
+    dispatcher.Register(EventA, func(_ Event) { order = append(order, "default-1") })
+    dispatcher.RegisterWithPriority(EventA, func(_ Event) { order = append(order, "low") }, -10)
+    dispatcher.RegisterWithPriority(EventA, func(_ Event) { order = append(order, "high") }, 10)
+    dispatcher.Register(EventA, func(_ Event) { order = append(order, "default-2") })
+
+    dispatcher.Dispatch(Make(EventA, nil))
+
+    expected := []string{"high", "default-1", "default-2", "low"}
+    if len(order) != len(expected) {
+        test.Fatalf("Expected %v, got %v", expected, order)
+    }
+    for i, name := range expected {
+        if order[i] != name {
+            test.Fatalf("Expected %v, got %v", expected, order)
+        }
+    }
+}
+
+func TestHighPriorityListenerCanVetoLowerPriorityOnes(test *testing.T) {
+    dispatcher := NewDispatcher()
+    lowPriorityRan := false
+
+    dispatcher.RegisterWithPriority(EventA, func(event Event) {
+        event.StopPropagation()
+    }, 10)
+    dispatcher.RegisterWithPriority(EventA, func(_ Event) {
+        lowPriorityRan = true
+    }, 0)
+
+    _, result := dispatcher.Dispatch(Make(EventA, nil))
+
+    if lowPriorityRan {
+        test.Fatal("The low priority listener shouldn't have run after propagation was stopped")
+    }
+    if !result.Stopped || result.Handled != 1 {
+        test.Fatal("The DispatchResult should report the dispatch was stopped after one listener ran")
+    }
+}
+
+func TestRegisterWithPriorityReturnsACancellableSubscription(test *testing.T) {
+    dispatcher := NewDispatcher()
+    ran := false
+
+    sub := dispatcher.RegisterWithPriority(EventA, func(_ Event) { ran = true }, 5)
+    sub.Cancel()
+
+    dispatcher.Dispatch(Make(EventA, nil))
+
+    if ran {
+        test.Fatal("A cancelled subscription shouldn't run")
+    }
+}
@@ -0,0 +1,56 @@
+package events
+
+import "testing"
+
+func TestStopsPropagation(test *testing.T) {
+    dispatcher := NewDispatcher()
+    secondRan := false
+
+    dispatcher.
+        Register(EventA, func(event Event) { event.StopPropagation() }).
+        Register(EventA, func(_ Event) { secondRan = true })
+
+    _, result := dispatcher.Dispatch(Make(EventA, nil))
+
+    if secondRan {
+        test.Fatal("The second listener shouldn't have run after propagation was stopped")
+    }
+    if result.Handled != 1 {
+        test.Fatal("Only one listener should have been reported as handled")
+    }
+    if !result.Stopped {
+        test.Fatal("`result.Stopped` should be `true`")
+    }
+}
+
+func TestReportsUnstoppedDispatches(test *testing.T) {
+    dispatcher := NewDispatcher()
+
+    dispatcher.
+        Register(EventA, func(_ Event) {}).
+        Register(EventA, func(_ Event) {})
+
+    _, result := dispatcher.Dispatch(Make(EventA, nil))
+
+    if result.Handled != 2 {
+        test.Fatal("Both listeners should have been reported as handled")
+    }
+    if result.Stopped {
+        test.Fatal("`result.Stopped` should be `false`")
+    }
+}
+
+func TestStopsPropagationAcrossPatternListeners(test *testing.T) {
+    dispatcher := NewDispatcher()
+    patternRan := false
+
+    dispatcher.
+        Register(EventA, func(event Event) { event.StopPropagation() }).
+        Register("*", func(_ Event) { patternRan = true })
+
+    dispatcher.Dispatch(Make(EventA, nil))
+
+    if patternRan {
+        test.Fatal("The pattern listener shouldn't have run after propagation was stopped")
+    }
+}
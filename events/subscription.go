@@ -0,0 +1,71 @@
+package events
+
+import (
+    "context"
+    "sync/atomic"
+)
+
+// Subscription represents a listener registered against a Dispatcher and lets
+// the caller cancel it later on. See Dispatcher.RegisterFunc.
+type Subscription interface {
+    // Cancel removes the listener this subscription was issued for.
+    Cancel()
+    // ID returns the subscription's identifier, unique across every
+    // dispatcher in the process.
+    ID() uint64
+}
+
+// subscriptionIds hands out unique subscription identifiers across every
+// dispatcher in the process.
+var subscriptionIds atomic.Uint64
+
+// nextSubscriptionId returns a new unique subscription identifier.
+func nextSubscriptionId() uint64 {
+    return subscriptionIds.Add(1)
+}
+
+// subscription backs every listener registered through Dispatcher.Register,
+// Dispatcher.Subscribe, Dispatcher.Once, Dispatcher.RegisterFunc and
+// Dispatcher.OnceFunc, whether stored as an exact match or, when the
+// registered id is a pattern, in dispatcher.patterns. Exactly one of
+// listener or fn is set, depending on whether the subscription was
+// registered through the bare Listener API or the context-aware ListenerFunc
+// one; see invoke. matches is nil for exact-match subscriptions.
+// registeredId is the id Register/Subscribe was originally called with (the
+// pattern itself for a pattern subscription), used by Dispatcher.Off to find
+// pattern subscriptions registered under a given id. priority orders a
+// subscription among the others registered under the same id; see
+// Dispatcher.RegisterWithPriority. cancelled is an atomic.Bool because
+// Cancel can race with a concurrent Dispatch reading it in run.
+type subscription struct {
+    id           uint64
+    listener     Listener
+    fn           ListenerFunc
+    matches      func(EventId) bool
+    registeredId EventId
+    cancelled    atomic.Bool
+    priority     int
+}
+
+// Cancel implements Subscription.
+func (sub *subscription) Cancel() {
+    sub.cancelled.Store(true)
+}
+
+// ID implements Subscription.
+func (sub *subscription) ID() uint64 {
+    return sub.id
+}
+
+// invoke runs sub's listener against event, threading ctx into it when sub
+// was registered through RegisterFunc/OnceFunc. A bare Listener never
+// produces an error.
+func (sub *subscription) invoke(ctx context.Context, event Event) error {
+    if sub.fn != nil {
+        return sub.fn(ctx, event)
+    }
+
+    sub.listener(event)
+
+    return nil
+}
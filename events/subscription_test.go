@@ -0,0 +1,143 @@
+package events
+
+import (
+    "sync"
+    "testing"
+)
+
+func TestCancelsASubscription(test *testing.T) {
+    dispatcher := NewDispatcher()
+    triggered := false
+
+    subscription := dispatcher.Subscribe(EventA, func(_ Event) { triggered = true })
+    subscription.Cancel()
+
+    dispatcher.Dispatch(Make(EventA, nil))
+
+    if triggered {
+        test.Fatal("A cancelled subscription's listener shouldn't run")
+    }
+}
+
+func TestCancelsAPatternSubscription(test *testing.T) {
+    dispatcher := NewDispatcher()
+    triggered := false
+
+    subscription := dispatcher.Subscribe("user.*", func(_ Event) { triggered = true })
+    subscription.Cancel()
+
+    dispatcher.Dispatch(Make("user.created", nil))
+
+    if triggered {
+        test.Fatal("A cancelled pattern subscription's listener shouldn't run")
+    }
+}
+
+func TestAssignsUniqueSubscriptionIds(test *testing.T) {
+    dispatcher := NewDispatcher()
+
+    first := dispatcher.Subscribe(EventA, func(_ Event) {})
+    second := dispatcher.Subscribe(EventA, func(_ Event) {})
+
+    if first.ID() == second.ID() {
+        test.Fatal("Each subscription should have a unique ID")
+    }
+}
+
+func TestRemovesListenersForAnEventId(test *testing.T) {
+    dispatcher := NewDispatcher()
+    triggered := false
+
+    dispatcher.Register(EventA, func(_ Event) { triggered = true })
+    dispatcher.Off(EventA)
+
+    dispatcher.Dispatch(Make(EventA, nil))
+
+    if triggered {
+        test.Fatal("Listeners removed with Off shouldn't run")
+    }
+}
+
+func TestRemovesPatternListenersForAnEventId(test *testing.T) {
+    dispatcher := NewDispatcher()
+    triggered := false
+
+    dispatcher.Register("user.*", func(_ Event) { triggered = true })
+    dispatcher.Off("user.*")
+
+    dispatcher.Dispatch(Make("user.created", nil))
+
+    if triggered {
+        test.Fatal("Pattern listeners removed with Off shouldn't run")
+    }
+}
+
+func TestOffLeavesOtherPatternsUntouched(test *testing.T) {
+    dispatcher := NewDispatcher()
+    triggered := false
+
+    dispatcher.Register("user.*", func(_ Event) { triggered = true })
+    dispatcher.Off("order.*")
+
+    dispatcher.Dispatch(Make("user.created", nil))
+
+    if !triggered {
+        test.Fatal("Off shouldn't remove patterns registered under a different id")
+    }
+}
+
+func TestRemovesEveryListener(test *testing.T) {
+    dispatcher := NewDispatcher()
+    triggered := false
+
+    dispatcher.Register(EventA, func(_ Event) { triggered = true })
+    dispatcher.Register("user.*", func(_ Event) { triggered = true })
+    dispatcher.RemoveAll()
+
+    dispatcher.Dispatch(Make(EventA, nil))
+    dispatcher.Dispatch(Make("user.created", nil))
+
+    if triggered {
+        test.Fatal("No listener should run after RemoveAll")
+    }
+}
+
+func TestOnceFiresOnlyOnce(test *testing.T) {
+    dispatcher := NewDispatcher()
+    calls := 0
+
+    dispatcher.Once(EventA, func(_ Event) { calls++ })
+
+    dispatcher.Dispatch(Make(EventA, nil))
+    dispatcher.Dispatch(Make(EventA, nil))
+
+    if calls != 1 {
+        test.Fatal("A Once listener should only be invoked once")
+    }
+}
+
+func TestOnceFiresExactlyOnceUnderConcurrentDispatches(test *testing.T) {
+    dispatcher := NewDispatcher()
+    var calls int
+    var mutex sync.Mutex
+
+    dispatcher.Once(EventA, func(_ Event) {
+        mutex.Lock()
+        calls++
+        mutex.Unlock()
+    })
+
+    waitGroup := new(sync.WaitGroup)
+    for i := 0; i < 10; i++ {
+        waitGroup.Add(1)
+        go func() {
+            defer waitGroup.Done()
+            dispatcher.Dispatch(Make(EventA, nil))
+        }()
+    }
+    waitGroup.Wait()
+
+    if calls != 1 {
+        test.Fatal("A Once listener should only be invoked once even when dispatched concurrently")
+    }
+}
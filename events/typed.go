@@ -0,0 +1,97 @@
+package events
+
+import (
+    "context"
+    "fmt"
+)
+
+// TypeMismatchError is reported when a listener registered through On,
+// MustOn or a TypedDispatcher is run against a payload that isn't of the
+// type it expected.
+type TypeMismatchError struct {
+    id       EventId
+    expected any
+    actual   any
+}
+
+// newTypeMismatchError creates a new TypeMismatchError.
+func newTypeMismatchError(id EventId, expected, actual any) error {
+    return &TypeMismatchError{id: id, expected: expected, actual: actual}
+}
+
+// Error returns the error message.
+func (err *TypeMismatchError) Error() string {
+    return fmt.Sprintf("events: listener for %q expected %T, got %T", err.id, err.expected, err.actual)
+}
+
+// On registers fn under id on dispatcher, removing the event.Get().(*T)
+// assertion boilerplate: fn only runs when the dispatched payload is of type
+// T. A payload of a different type is reported to Config.OnListenerError as a
+// TypeMismatchError instead of panicking; see MustOn to panic instead.
+func On[T any](dispatcher *Dispatcher, id EventId, fn func(T)) Subscription {
+    return dispatcher.Subscribe(id, func(event Event) {
+        payload, ok := event.Get().(T)
+        if !ok {
+            reportTypeMismatch(dispatcher, id, payload, event.Get())
+            return
+        }
+
+        fn(payload)
+    })
+}
+
+// MustOn works like On but panics instead of reporting a TypeMismatchError
+// when the dispatched payload isn't of type T.
+func MustOn[T any](dispatcher *Dispatcher, id EventId, fn func(T)) Subscription {
+    return dispatcher.Subscribe(id, func(event Event) {
+        fn(event.Get().(T))
+    })
+}
+
+// Emit builds an Event carrying payload and dispatches it on dispatcher under
+// id. The returned error is whatever Dispatcher.DispatchContext aggregated
+// from the listeners registered for id.
+func Emit[T any](dispatcher *Dispatcher, id EventId, payload T) error {
+    _, _, err := dispatcher.DispatchContext(context.Background(), Make(id, payload))
+
+    return err
+}
+
+// reportTypeMismatch delivers a TypeMismatchError to dispatcher's
+// Config.OnListenerError hook, if one was configured.
+func reportTypeMismatch(dispatcher *Dispatcher, id EventId, expected, actual any) {
+    if dispatcher.config.onListenerError == nil {
+        return
+    }
+
+    dispatcher.config.onListenerError(id, newTypeMismatchError(id, expected, actual))
+}
+
+// TypedDispatcher binds a single event ID to a single payload type T so
+// callers don't have to repeat the ID or the type assertion at every call
+// site. Build one with NewTypedDispatcher.
+type TypedDispatcher[T any] struct {
+    dispatcher *Dispatcher
+    id         EventId
+}
+
+// NewTypedDispatcher builds a TypedDispatcher bound to id on dispatcher.
+func NewTypedDispatcher[T any](dispatcher *Dispatcher, id EventId) TypedDispatcher[T] {
+    return TypedDispatcher[T]{dispatcher: dispatcher, id: id}
+}
+
+// On registers fn to run whenever the bound ID is dispatched with a payload
+// of type T.
+func (typed TypedDispatcher[T]) On(fn func(T)) Subscription {
+    return On(typed.dispatcher, typed.id, fn)
+}
+
+// MustOn works like On but panics instead of reporting a TypeMismatchError.
+func (typed TypedDispatcher[T]) MustOn(fn func(T)) Subscription {
+    return MustOn(typed.dispatcher, typed.id, fn)
+}
+
+// Emit dispatches payload under the bound ID.
+func (typed TypedDispatcher[T]) Emit(payload T) error {
+    return Emit(typed.dispatcher, typed.id, payload)
+}
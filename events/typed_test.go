@@ -0,0 +1,71 @@
+package events
+
+import "testing"
+
+type userCreated struct {
+    name string
+}
+
+func TestOnDispatchesTypedPayloads(test *testing.T) {
+    dispatcher := NewDispatcher()
+    var received string
+
+    On(dispatcher, EventA, func(payload userCreated) {
+        received = payload.name
+    })
+
+    dispatcher.Dispatch(Make(EventA, userCreated{name: "ana"}))
+
+    if received != "ana" {
+        test.Fatal("The listener should have received the typed payload")
+    }
+}
+
+func TestOnReportsTypeMismatchesThroughTheHook(test *testing.T) {
+    var reported error
+
+    dispatcher := NewDispatcher(func(config *Config) {
+        config.OnListenerError(func(_ EventId, err error) {
+            reported = err
+        })
+    })
+    On(dispatcher, EventA, func(_ userCreated) {
+        test.Fatal("The listener shouldn't run for a mismatching payload")
+    })
+
+    dispatcher.Dispatch(Make(EventA, "not a userCreated"))
+
+    if _, ok := reported.(*TypeMismatchError); !ok {
+        test.Fatal("A TypeMismatchError should have been reported")
+    }
+}
+
+func TestMustOnPanicsOnTypeMismatch(test *testing.T) {
+    defer func() {
+        if recover() == nil {
+            test.Fatal("MustOn should panic when the payload doesn't match")
+        }
+    }()
+
+    dispatcher := NewDispatcher()
+    MustOn(dispatcher, EventA, func(_ userCreated) {})
+
+    dispatcher.Dispatch(Make(EventA, "not a userCreated"))
+}
+
+func TestEmitDispatchesTypedPayloadsWithoutError(test *testing.T) {
+    dispatcher := NewDispatcher()
+    typed := NewTypedDispatcher[userCreated](dispatcher, EventA)
+    var received userCreated
+
+    typed.On(func(payload userCreated) { received = payload })
+
+    err := typed.Emit(userCreated{name: "bob"})
+
+    if err != nil {
+        test.Fatal("There shouldn't be an error when every listener succeeds")
+    }
+    if received.name != "bob" {
+        test.Fatal("The listener should have received the typed payload")
+    }
+}